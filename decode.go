@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// decodeQRCodeFile reads an image file and decodes the QR code it contains,
+// returning the raw payload text.
+func decodeQRCodeFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot open image file %s: %v", path, err)
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode image %s: %v", path, err)
+	}
+
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return "", fmt.Errorf("cannot read QR bitmap from %s: %v", path, err)
+	}
+
+	result, err := qrcode.NewQRCodeReader().Decode(bitmap, nil)
+	if err != nil {
+		return "", fmt.Errorf("cannot find a QR code in %s: %v", path, err)
+	}
+
+	return result.GetText(), nil
+}
+
+// runDecode decodes the QR image at config.Decode and prints or saves its
+// payload, recognizing the same special envelopes the encode side
+// produces (WiFi, vCard, otpauth, chunk envelopes, and data URIs).
+func runDecode(config Config) error {
+	text, err := decodeQRCodeFile(config.Decode)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case strings.HasPrefix(text, "WIFI:"):
+		printWiFiFields(text)
+	case strings.HasPrefix(text, "BEGIN:VCARD"):
+		return saveDecodedFile(text, config.DecodeOut, "contact.vcf")
+	case strings.HasPrefix(text, "otpauth://"):
+		return printOTPFields(text)
+	case strings.HasPrefix(text, "CHUNK:"):
+		envelope, err := parseChunkEnvelope(text)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📦 Chunk %d/%d (set %s, %d bytes)\n", envelope.Index, envelope.Total, envelope.ID, len(envelope.Payload))
+		fmt.Println("   Use --decode-chunks <dir> to reassemble the full set.")
+	case strings.HasPrefix(text, "data:"):
+		return saveDataURI(text, config.DecodeOut)
+	default:
+		fmt.Println(text)
+	}
+
+	return nil
+}
+
+// printWiFiFields prints the SSID/password/security fields of a
+// "WIFI:T:...;S:...;P:...;H:...;" payload.
+func printWiFiFields(text string) {
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimSuffix(text, ";"), ";") {
+		kv := strings.SplitN(strings.TrimPrefix(part, "WIFI:"), ":", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+
+	fmt.Println("📶 WiFi credentials:")
+	fmt.Printf("   SSID:     %s\n", fields["S"])
+	fmt.Printf("   Password: %s\n", fields["P"])
+	fmt.Printf("   Security: %s\n", fields["T"])
+}
+
+// printOTPFields prints the parsed parameters of an otpauth:// URI.
+func printOTPFields(text string) error {
+	u, err := url.Parse(text)
+	if err != nil {
+		return fmt.Errorf("cannot parse otpauth URI: %v", err)
+	}
+
+	query := u.Query()
+	fmt.Printf("🔐 OTP type:   %s\n", u.Host)
+	fmt.Printf("   Label:      %s\n", strings.TrimPrefix(u.Path, "/"))
+	fmt.Printf("   Secret:     %s\n", query.Get("secret"))
+	if issuer := query.Get("issuer"); issuer != "" {
+		fmt.Printf("   Issuer:     %s\n", issuer)
+	}
+	fmt.Printf("   Algorithm:  %s\n", orDefault(query.Get("algorithm"), "SHA1"))
+	fmt.Printf("   Digits:     %s\n", orDefault(query.Get("digits"), "6"))
+	if u.Host == "hotp" {
+		fmt.Printf("   Counter:    %s\n", query.Get("counter"))
+	} else {
+		fmt.Printf("   Period:     %s\n", orDefault(query.Get("period"), "30"))
+	}
+
+	return nil
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// saveDataURI decodes a "data:<mime>;base64,<payload>" URI and writes the
+// binary payload to outputPath (or defaultName if outputPath is empty).
+func saveDataURI(text, outputPath string) error {
+	commaIdx := strings.Index(text, ",")
+	if commaIdx < 0 || !strings.Contains(text[:commaIdx], ";base64") {
+		return fmt.Errorf("unsupported data URI (expected base64 encoding)")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(text[commaIdx+1:])
+	if err != nil {
+		return fmt.Errorf("cannot decode data URI payload: %v", err)
+	}
+
+	if outputPath == "" {
+		return fmt.Errorf("--decode-out is required to save a data URI payload")
+	}
+	return os.WriteFile(outputPath, data, 0644)
+}
+
+// saveDecodedFile writes text to outputPath, falling back to defaultName
+// if outputPath is empty.
+func saveDecodedFile(text, outputPath, defaultName string) error {
+	if outputPath == "" {
+		outputPath = defaultName
+	}
+	if err := os.WriteFile(outputPath, []byte(text), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("📇 Saved to %s\n", outputPath)
+	return nil
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// supportedFormats lists the output formats generateQRCode understands,
+// dispatched either from --format or from the output file's extension.
+var supportedFormats = map[string]bool{
+	"png": true, "jpg": true, "jpeg": true,
+	"svg": true, "pdf": true, "eps": true, "txt": true,
+}
+
+// detectFormat resolves the output format for outputPath: an explicit
+// --format flag wins, otherwise it's inferred from the file extension,
+// falling back to png.
+func detectFormat(format, outputPath string) (string, error) {
+	if format != "" {
+		format = strings.ToLower(format)
+	} else {
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(outputPath), "."))
+		if ext == "" {
+			ext = "png"
+		}
+		format = ext
+	}
+
+	if !supportedFormats[format] {
+		return "", fmt.Errorf("unsupported output format %q (supported: png, jpg, svg, pdf, eps, txt)", format)
+	}
+	return format, nil
+}
+
+func writePNGFile(qr *qrcode.QRCode, size int, path string, style qrStyle) error {
+	img, err := renderStyledImage(qr.Bitmap(), size, style)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("cannot create file %s: %v", path, err)
+	}
+	defer file.Close()
+
+	return png.Encode(file, img)
+}
+
+func writeJPEGFile(qr *qrcode.QRCode, size int, path string, style qrStyle) error {
+	img, err := renderStyledImage(qr.Bitmap(), size, style)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("cannot create file %s: %v", path, err)
+	}
+	defer file.Close()
+
+	return jpeg.Encode(file, img, &jpeg.Options{Quality: 90})
+}
+
+func writeSVGFile(qr *qrcode.QRCode, size int, path string, style qrStyle) error {
+	var logoURI string
+	if style.Logo != "" {
+		uri, err := logoDataURI(style.Logo)
+		if err != nil {
+			return err
+		}
+		logoURI = uri
+	}
+
+	bitmap := qr.Bitmap()
+	modules := len(bitmap) + 2*style.Margin
+	fg := hexString(style.FG)
+	bg := hexString(style.BG)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d">`+"\n", modules, modules, size, size)
+	if style.Transparent {
+		b.WriteString(`<rect width="100%" height="100%" fill="none"/>` + "\n")
+	} else {
+		fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", bg)
+	}
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&b, `<rect x="%d" y="%d" width="1" height="1" fill="%s"/>`+"\n", x+style.Margin, y+style.Margin, fg)
+			}
+		}
+	}
+
+	if logoURI != "" {
+		logoSize := float64(modules) * math.Sqrt(style.LogoScale)
+		offset := (float64(modules) - logoSize) / 2
+		fmt.Fprintf(&b, `<image x="%.3f" y="%.3f" width="%.3f" height="%.3f" href="%s"/>`+"\n", offset, offset, logoSize, logoSize, logoURI)
+	}
+
+	b.WriteString(`</svg>` + "\n")
+
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// hexString renders a color as a "#rrggbb" string for SVG fill attributes.
+func hexString(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func writeTXTFile(qr *qrcode.QRCode, path string, style qrStyle) error {
+	bitmap := qr.Bitmap()
+	n := len(bitmap)
+	modules := n + 2*style.Margin
+
+	isDark := func(row, col int) bool {
+		row -= style.Margin
+		col -= style.Margin
+		if row < 0 || row >= n || col < 0 || col >= n {
+			return false
+		}
+		return bitmap[row][col]
+	}
+
+	var b strings.Builder
+	for y := 0; y < modules; y += 2 {
+		for x := 0; x < modules; x++ {
+			top := isDark(y, x)
+			bottom := isDark(y+1, x)
+			switch {
+			case top && bottom:
+				b.WriteRune('█')
+			case top && !bottom:
+				b.WriteRune('▀')
+			case !top && bottom:
+				b.WriteRune('▄')
+			default:
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// writeEPSFile renders the QR as an Encapsulated PostScript document, one
+// filled square per dark module, for print workflows. style's colors and
+// margin apply the same as the raster/SVG writers; transparency has no
+// meaning on paper and is ignored.
+func writeEPSFile(qr *qrcode.QRCode, size int, path string, style qrStyle) error {
+	bitmap := qr.Bitmap()
+	n := len(bitmap)
+	modules := n + 2*style.Margin
+	scale := float64(size) / float64(modules)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%%!PS-Adobe-3.0 EPSF-3.0\n%%%%BoundingBox: 0 0 %d %d\n", size, size)
+	b.WriteString("/m { newpath moveto 0 1 rlineto 1 0 rlineto 0 -1 rlineto closepath fill } bind def\n")
+	fmt.Fprintf(&b, "%f %f scale\n", scale, scale)
+	fmt.Fprintf(&b, "%s setrgbcolor\n0 0 %d %d rectfill\n", epsColor(style.BG), modules, modules)
+	fmt.Fprintf(&b, "%s setrgbcolor\n", epsColor(style.FG))
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				// Flip Y since PostScript's origin is bottom-left.
+				fmt.Fprintf(&b, "%d %d m\n", x+style.Margin, modules-1-(y+style.Margin))
+			}
+		}
+	}
+	b.WriteString("%%EOF\n")
+
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
+
+// epsColor renders a color as PostScript "setrgbcolor" operands (0-1 range).
+func epsColor(c color.RGBA) string {
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+// writePDFFile embeds the QR as vector rectangles in a single-page PDF,
+// sized to size x size PDF points (72 DPI). style's colors and margin apply
+// the same as the raster/SVG writers; transparency has no meaning on a PDF
+// page and is ignored.
+func writePDFFile(qr *qrcode.QRCode, size int, path string, style qrStyle) error {
+	bitmap := qr.Bitmap()
+	n := len(bitmap)
+	modules := n + 2*style.Margin
+	scale := float64(size) / float64(modules)
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "%s rg\n0 0 %d %d re f\n", pdfColor(style.BG), size, size)
+	fmt.Fprintf(&content, "%s rg\n", pdfColor(style.FG))
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			px := float64(x+style.Margin) * scale
+			// PDF space has the origin at the bottom-left.
+			py := float64(n-1-y+style.Margin) * scale
+			fmt.Fprintf(&content, "%.2f %.2f %.2f %.2f re f\n", px, py, scale, scale)
+		}
+	}
+
+	return writeSinglePagePDF(path, size, size, content.String())
+}
+
+// pdfColor renders a color as PDF "rg" fill operands (0-1 range).
+func pdfColor(c color.RGBA) string {
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+// writeSinglePagePDF assembles a minimal single-page PDF document with the
+// given page dimensions and raw content stream.
+func writeSinglePagePDF(path string, width, height int, content string) error {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Contents 4 0 R /Resources << >> >>", width, height),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+	}
+
+	var b strings.Builder
+	b.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xrefStart := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n", len(objects)+1)
+	b.WriteString("0000000000 65535 f \n")
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF", len(objects)+1, xrefStart)
+
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
@@ -37,6 +37,58 @@ type Config struct {
 	Quality string
 	Batch   bool
 	Preview bool
+
+	Chunks       bool
+	ChunkSize    int
+	DecodeChunks string
+
+	TOTP             string
+	TOTPIssuer       string
+	TOTPAccount      string
+	TOTPSecret       string
+	TOTPDigits       int
+	TOTPPeriod       int
+	TOTPAlgo         string
+	TOTPSecretFormat string
+
+	HOTP        string
+	HOTPCounter int
+
+	Format string
+
+	FG          string
+	BG          string
+	Transparent bool
+	Margin      int
+	Logo        string
+	LogoScale   float64
+
+	OutDir string
+
+	Decode    string
+	DecodeOut string
+
+	VCardName     string
+	VCardOrg      string
+	VCardTitle    string
+	VCardTel      string
+	VCardEmail    string
+	VCardAdr      string
+	VCardURLField string
+	VCardNote     string
+	VCardPhoto    string
+	MeCard        bool
+
+	SMS    string
+	Mailto string
+	Geo    string
+	Tel    string
+
+	Event         bool
+	EventSummary  string
+	EventLocation string
+	EventStart    string
+	EventEnd      string
 }
 
 func main() {
@@ -52,6 +104,49 @@ func main() {
 		return
 	}
 
+	if config.Decode != "" {
+		if err := runDecode(config); err != nil {
+			fmt.Fprintf(os.Stderr, "Error decoding QR code: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if config.DecodeChunks != "" {
+		payload, err := reassembleChunks(config.DecodeChunks)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reassembling chunks: %v\n", err)
+			os.Exit(1)
+		}
+
+		output := config.Output
+		if output == "" || output == defaultOutput {
+			output = "reassembled.bin"
+		}
+		if err := os.WriteFile(output, payload, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing reassembled payload: %v\n", err)
+			os.Exit(1)
+		}
+
+		if !config.Quiet {
+			fmt.Printf("✅ Reassembled %d bytes from %s\n", len(payload), config.DecodeChunks)
+			fmt.Printf("📁 Output: %s\n", output)
+		}
+		return
+	}
+
+	if config.Batch && config.File != "" {
+		summary, err := processBatchFile(config.File, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if !config.Quiet {
+			fmt.Println(summary)
+		}
+		return
+	}
+
 	// Validate and get input content
 	content, err := getInputContent(config)
 	if err != nil {
@@ -72,18 +167,17 @@ func main() {
 	}
 
 	// Set quality level
-	var recoveryLevel qrcode.RecoveryLevel
-	switch strings.ToLower(config.Quality) {
-	case "low", "l":
-		recoveryLevel = qrcode.Low
-	case "medium", "m":
-		recoveryLevel = qrcode.Medium
-	case "high", "h":
-		recoveryLevel = qrcode.High
-	case "highest", "hh":
+	recoveryLevel := recoveryLevelFromQuality(config.Quality)
+
+	style, err := resolveStyle(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if style.Logo != "" {
+		// A logo covers modules in the middle of the QR; the highest
+		// recovery level keeps the code scannable despite the coverage.
 		recoveryLevel = qrcode.Highest
-	default:
-		recoveryLevel = qrcode.Medium
 	}
 
 	// Generate QR code
@@ -91,7 +185,26 @@ func main() {
 		showASCIIPreview(content)
 	}
 
-	err = generateQRCode(content, config.Output, config.Size, recoveryLevel)
+	if config.Chunks {
+		prefix := strings.TrimSuffix(config.Output, filepath.Ext(config.Output))
+		if prefix == "" || config.Output == defaultOutput {
+			prefix = "qr"
+		}
+		paths, err := writeChunkQRCodes([]byte(content), prefix, config.ChunkSize, config.Size, recoveryLevel, style)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating chunked QR codes: %v\n", err)
+			os.Exit(1)
+		}
+		if !config.Quiet {
+			fmt.Printf("✅ Generated %d chunk QR codes:\n", len(paths))
+			for _, path := range paths {
+				fmt.Printf("📁 %s\n", path)
+			}
+		}
+		return
+	}
+
+	err = generateStyledQRCode(content, config.Output, config.Size, recoveryLevel, config.Format, style, false)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating QR code: %v\n", err)
 		os.Exit(1)
@@ -136,7 +249,58 @@ func parseFlags() Config {
 	flag.StringVar(&config.Quality, "q", "medium", "Error correction level (shorthand)")
 
 	flag.BoolVar(&config.Batch, "batch", false, "Batch mode - process multiple inputs from file")
+	flag.StringVar(&config.OutDir, "out-dir", "", "Directory batch output files are written to (default: current directory)")
+
+	flag.StringVar(&config.Decode, "decode", "", "Decode a QR code image and print its payload")
+	flag.StringVar(&config.DecodeOut, "decode-out", "", "Output file for decoded vCard/data-URI payloads")
+
+	flag.StringVar(&config.VCardName, "name", "", "Contact name (vCard/MeCard)")
+	flag.StringVar(&config.VCardOrg, "org", "", "Contact organization (vCard/MeCard)")
+	flag.StringVar(&config.VCardTitle, "title", "", "Contact job title (vCard)")
+	flag.StringVar(&config.VCardTel, "vcard-tel", "", "Contact phone number (vCard/MeCard)")
+	flag.StringVar(&config.VCardEmail, "email", "", "Contact email (vCard/MeCard)")
+	flag.StringVar(&config.VCardAdr, "adr", "", "Contact address (vCard/MeCard)")
+	flag.StringVar(&config.VCardURLField, "vcard-url", "", "Contact URL (vCard/MeCard)")
+	flag.StringVar(&config.VCardNote, "note", "", "Contact note (vCard/MeCard)")
+	flag.StringVar(&config.VCardPhoto, "photo", "", "Contact photo file, embedded as base64 (vCard)")
+	flag.BoolVar(&config.MeCard, "mecard", false, "Build a compact MeCard instead of a vCard from the contact flags")
+
+	flag.StringVar(&config.SMS, "sms", "", "SMS shorthand: '+number:message'")
+	flag.StringVar(&config.Mailto, "mailto", "", "mailto: shorthand: 'address[?subject=...&body=...]'")
+	flag.StringVar(&config.Geo, "geo", "", "Geo shorthand: 'lat,lon[,alt]'")
+	flag.StringVar(&config.Tel, "tel", "", "tel: shorthand: '+number'")
+
+	flag.BoolVar(&config.Event, "event", false, "Build an iCal VEVENT from the --event-* flags")
+	flag.StringVar(&config.EventSummary, "event-summary", "", "Event title")
+	flag.StringVar(&config.EventLocation, "event-location", "", "Event location")
+	flag.StringVar(&config.EventStart, "event-start", "", "Event start, e.g. 20260901T090000")
+	flag.StringVar(&config.EventEnd, "event-end", "", "Event end, e.g. 20260901T100000")
 	flag.BoolVar(&config.Preview, "preview", false, "Show ASCII QR preview in terminal")
+
+	flag.BoolVar(&config.Chunks, "chunks", false, "Split large input across multiple QR codes (qr_001.png, qr_002.png, ...)")
+	flag.IntVar(&config.ChunkSize, "chunk-size", 0, "Override the per-chunk byte budget (default: derived from -q)")
+	flag.StringVar(&config.DecodeChunks, "decode-chunks", "", "Reassemble a directory of chunk QR codes into the original payload")
+
+	flag.StringVar(&config.TOTP, "totp", "", "TOTP shorthand: 'issuer:account:secret'")
+	flag.StringVar(&config.TOTPIssuer, "totp-issuer", "", "TOTP issuer name")
+	flag.StringVar(&config.TOTPAccount, "totp-account", "", "TOTP account name")
+	flag.StringVar(&config.TOTPSecret, "totp-secret", "", "TOTP secret (hex or base32)")
+	flag.IntVar(&config.TOTPDigits, "totp-digits", 6, "TOTP code digits")
+	flag.IntVar(&config.TOTPPeriod, "totp-period", 30, "TOTP period in seconds")
+	flag.StringVar(&config.TOTPAlgo, "totp-algo", "SHA1", "TOTP algorithm (SHA1/SHA256/SHA512)")
+	flag.StringVar(&config.TOTPSecretFormat, "totp-secret-format", "", "Force the secret encoding: hex or base32 (default: auto-detect)")
+
+	flag.StringVar(&config.HOTP, "hotp", "", "HOTP shorthand: 'issuer:account:secret:counter'")
+	flag.IntVar(&config.HOTPCounter, "hotp-counter", 0, "HOTP counter value")
+
+	flag.StringVar(&config.Format, "format", "", "Output format: png/jpg/svg/pdf/eps/txt (default: inferred from -o extension)")
+
+	flag.StringVar(&config.FG, "fg", "", "Foreground (module) color, e.g. #000000")
+	flag.StringVar(&config.BG, "bg", "", "Background color, e.g. #ffffff")
+	flag.BoolVar(&config.Transparent, "transparent", false, "Transparent background (png/svg only)")
+	flag.IntVar(&config.Margin, "margin", 0, "Quiet zone size in modules added around the QR")
+	flag.StringVar(&config.Logo, "logo", "", "Logo image to overlay at the center of the QR")
+	flag.Float64Var(&config.LogoScale, "logo-scale", 0.2, "Logo size as a fraction of the QR area (max 0.25)")
 	flag.BoolVar(&config.Quiet, "quiet", false, "Quiet mode - no output messages")
 	flag.BoolVar(&config.Help, "help", false, "Show help message")
 	flag.BoolVar(&config.Help, "h", false, "Show help message (shorthand)")
@@ -148,19 +312,54 @@ func parseFlags() Config {
 }
 
 func getInputContent(config Config) (string, error) {
-	// Priority: batch -> vcard -> wifi -> image -> file -> url -> text
-	if config.Batch && config.File != "" {
-		return processBatchFile(config.File)
-	}
-
+	// Priority: vcard -> mecard -> wifi -> totp/hotp ->
+	// sms/mailto/geo/tel/event -> image -> file -> url -> text
+	// (batch mode is handled directly in main, before this is called, since
+	// it produces a run summary rather than QR content)
 	if config.VCard != "" {
 		return readFromFile(config.VCard)
 	}
 
+	if config.MeCard {
+		return buildMeCard(vCardFieldsFromConfig(config))
+	}
+
+	if hasVCardFields(config) {
+		return buildVCard(vCardFieldsFromConfig(config))
+	}
+
 	if config.WiFi != "" {
 		return generateWiFiQR(config.WiFi)
 	}
 
+	if config.TOTP != "" || config.TOTPAccount != "" {
+		return generateOTPURI(config, "totp")
+	}
+
+	if config.HOTP != "" {
+		return generateOTPURI(config, "hotp")
+	}
+
+	if config.SMS != "" {
+		return buildSMS(config.SMS)
+	}
+
+	if config.Mailto != "" {
+		return buildMailto(config.Mailto)
+	}
+
+	if config.Geo != "" {
+		return buildGeo(config.Geo)
+	}
+
+	if config.Tel != "" {
+		return buildTelURI(config.Tel)
+	}
+
+	if config.Event {
+		return buildEvent(config)
+	}
+
 	if config.Image != "" {
 		return encodeImageToBase64(config.Image)
 	}
@@ -219,13 +418,49 @@ func isValidURL(rawURL string) bool {
 }
 
 func generateQRCode(content, outputPath string, size int, recoveryLevel qrcode.RecoveryLevel) error {
+	return generateQRCodeFormat(content, outputPath, size, recoveryLevel, "")
+}
+
+// generateQRCodeFormat generates a QR code for content and writes it to
+// outputPath in the given format (png/jpg/svg/pdf/eps/txt), inferring the
+// format from outputPath's extension when format is empty. Colors, quiet
+// zone, and logo overlay use the package defaults; see
+// generateStyledQRCode for the styled entry point.
+func generateQRCodeFormat(content, outputPath string, size int, recoveryLevel qrcode.RecoveryLevel, format string) error {
+	return generateStyledQRCode(content, outputPath, size, recoveryLevel, format, defaultStyle, false)
+}
+
+// generateQRCodeNonInteractive is generateQRCode without the interactive
+// overwrite prompt, for unattended callers (e.g. batch mode) that already
+// record per-file success/failure themselves.
+func generateQRCodeNonInteractive(content, outputPath string, size int, recoveryLevel qrcode.RecoveryLevel) error {
+	return generateStyledQRCode(content, outputPath, size, recoveryLevel, "", defaultStyle, true)
+}
+
+// generateStyledQRCode is generateQRCodeFormat with explicit control over
+// module colors, quiet zone, logo overlay, and whether an existing output
+// file prompts for overwrite confirmation (force skips the prompt).
+func generateStyledQRCode(content, outputPath string, size int, recoveryLevel qrcode.RecoveryLevel, format string, style qrStyle, force bool) error {
+	resolvedFormat, err := detectFormat(format, outputPath)
+	if err != nil {
+		return err
+	}
+	if style.Logo != "" {
+		switch resolvedFormat {
+		case "pdf", "eps", "txt":
+			return fmt.Errorf("--logo is only supported for png, jpg, and svg output, not %s", resolvedFormat)
+		}
+	}
+
 	// Check if output file already exists
-	if _, err := os.Stat(outputPath); err == nil {
-		fmt.Printf("⚠️  File %s already exists. Overwrite? (y/N): ", outputPath)
-		var response string
-		fmt.Scanln(&response)
-		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
-			return fmt.Errorf("operation cancelled")
+	if !force {
+		if _, err := os.Stat(outputPath); err == nil {
+			fmt.Printf("⚠️  File %s already exists. Overwrite? (y/N): ", outputPath)
+			var response string
+			fmt.Scanln(&response)
+			if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+				return fmt.Errorf("operation cancelled")
+			}
 		}
 	}
 
@@ -244,8 +479,20 @@ func generateQRCode(content, outputPath string, size int, recoveryLevel qrcode.R
 		return fmt.Errorf("cannot create QR code: %v", err)
 	}
 
-	// Write to file
-	err = qr.WriteFile(size, outputPath)
+	switch resolvedFormat {
+	case "png":
+		err = writePNGFile(qr, size, outputPath, style)
+	case "jpg", "jpeg":
+		err = writeJPEGFile(qr, size, outputPath, style)
+	case "svg":
+		err = writeSVGFile(qr, size, outputPath, style)
+	case "pdf":
+		err = writePDFFile(qr, size, outputPath, style)
+	case "eps":
+		err = writeEPSFile(qr, size, outputPath, style)
+	case "txt":
+		err = writeTXTFile(qr, outputPath, style)
+	}
 	if err != nil {
 		return fmt.Errorf("cannot write QR code to file: %v", err)
 	}
@@ -281,12 +528,39 @@ INPUT OPTIONS:
     -i, --image     Image file to encode as base64 data URI
     -w, --wifi      WiFi credentials (SSID:PASSWORD:SECURITY)
     --vcard         vCard file (.vcf) for contact info
+    --name, --org, --title, --vcard-tel, --email, --adr, --vcard-url,
+    --note, --photo
+                    Build a vCard in-memory from structured contact fields
+    --mecard        Build a compact MeCard instead of a vCard
+    --sms           SMS shorthand '+number:message' (SMSTO:...)
+    --mailto        mailto: shorthand 'address[?subject=...&body=...]'
+    --geo           Geo shorthand 'lat,lon[,alt]' (geo:...)
+    --tel           tel: shorthand '+number'
+    --event         Build an iCal VEVENT (with --event-summary/-location/-start/-end)
     --batch         Batch process multiple inputs from file
+    --out-dir       Directory batch output files are written to
+    --chunks        Split large input across multiple QR codes
+    --chunk-size N  Override the per-chunk byte budget
+    --decode-chunks Reassemble a directory of chunk QR codes
+    --decode IMAGE  Decode a QR code image (PNG/JPG) and print its payload
+    --decode-out    Output file for decoded vCard/data-URI payloads
+    --totp          TOTP shorthand 'issuer:account:secret' (otpauth://totp/...)
+    --totp-issuer, --totp-account, --totp-secret, --totp-digits,
+    --totp-period, --totp-algo, --totp-secret-format
+                    TOTP fields for authenticator apps
+    --hotp          HOTP shorthand 'issuer:account:secret:counter'
+    --hotp-counter  HOTP counter value
 
 OUTPUT OPTIONS:
     -o, --output    Output file name (default: qr.png)
     -s, --size      QR code size in pixels (default: 256)
     -q, --quality   Error correction: low/medium/high/highest (default: medium)
+    --format        Output format: png/jpg/svg/pdf/eps/txt (default: inferred from -o)
+    --fg, --bg      Module/background color, e.g. #000000 / #ffffff
+    --transparent   Transparent background (png/svg only)
+    --margin N      Quiet zone size in modules added around the QR
+    --logo PATH     Logo image to overlay at the center of the QR (png/jpg/svg only)
+    --logo-scale F  Logo size as a fraction of the QR area (max 0.25, default 0.2)
     --preview       Show ASCII QR preview in terminal
     --quiet         Quiet mode - no output messages
 
@@ -315,21 +589,54 @@ EXAMPLES:
     
     # Preview in terminal
     qrgen -t "Preview Test" --preview
+
+    # Split a large file across several QR codes
+    qrgen -f bigfile.txt --chunks -o qr.png
+
+    # Reassemble a directory of chunk QR codes
+    qrgen --decode-chunks ./chunks -o bigfile.txt
+
+    # TOTP QR for an authenticator app
+    qrgen --totp "GitHub:me@example.com:JBSWY3DPEHPK3PXP" -o totp.png
+
+    # Scalable vector output
+    qrgen -t "Hello World!" -o qr.svg
+
+    # Terminal-friendly text output
+    qrgen -t "Hello World!" -o qr.txt
+
+    # Branded QR with colors and a centered logo
+    qrgen -u "https://example.com" --fg "#1a1a2e" --bg "#f5f5f5" --logo logo.png -o brand.png
+
+    # Decode a QR code back to text
+    qrgen --decode qr.png
+
+    # Contact QR built from structured fields, no .vcf file needed
+    qrgen --name "Jane Doe" --org "Acme" --vcard-tel "+1234567890" --email jane@acme.com -o jane.png
+
+    # Geo, SMS, and mailto shortcuts
+    qrgen --geo "37.7749,-122.4194" -o geo.png
+    qrgen --sms "+1234567890:Running late" -o sms.png
+    qrgen --mailto "jane@acme.com?subject=Hi" -o mailto.png
     
     # High quality with preview
     qrgen -u "https://important-site.com" -q highest --preview
 
 SUPPORTED FORMATS:
     Input Images: PNG, JPG, JPEG, GIF, WebP
-    Output: PNG only
+    Output: PNG, JPG, SVG, PDF, EPS, TXT (UTF-8 terminal art)
     WiFi Security: WPA, WEP, nopass
     
 BATCH FILE FORMAT:
     # Lines starting with # are comments
-    https://github.com/user1
+    # content[,output[,size[,quality]]] - CSV or TSV, trailing fields optional
+    https://github.com/user1,user1.png,512,high
     Contact: +1234567890
     https://example.com
-    
+
+    Batch runs across runtime.NumCPU() workers and writes manifest.json
+    (input, output, sha256, bytes, status, error) alongside the outputs.
+
 AUTHOR:
     Generated with ❤️ using Go
 `, version)
@@ -398,36 +705,6 @@ func generateWiFiQR(wifiConfig string) (string, error) {
 	return wifiQR, nil
 }
 
-func processBatchFile(filename string) (string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return "", fmt.Errorf("cannot open batch file %s: %v", filename, err)
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	lineNum := 1
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		// Process each line as separate QR
-		outputFile := fmt.Sprintf("batch_%d.png", lineNum)
-		err := generateQRCode(line, outputFile, 256, qrcode.Medium)
-		if err != nil {
-			fmt.Printf("❌ Error processing line %d: %v\n", lineNum, err)
-		} else {
-			fmt.Printf("✅ Generated: %s\n", outputFile)
-		}
-		lineNum++
-	}
-
-	return fmt.Sprintf("Batch processing completed. Generated %d QR codes.", lineNum-1), nil
-}
-
 func showASCIIPreview(content string) {
 	fmt.Printf("\n📱 ASCII QR Preview:\n")
 	fmt.Println("╭─────────────────────╮")
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// otpConfig holds the fields needed to build an otpauth:// URI for either a
+// TOTP or HOTP authenticator entry.
+type otpConfig struct {
+	Type    string // "totp" or "hotp"
+	Issuer  string
+	Account string
+	Secret  string // raw secret, hex or base32
+	Digits  int
+	Period  int // TOTP only
+	Counter int // HOTP only
+	Algo    string
+
+	// SecretFormat disambiguates Secret as "hex" or "base32". Empty means
+	// auto-detect: strict hex digits decode as hex, everything else is
+	// tried as base32.
+	SecretFormat string
+}
+
+// buildOTPURI constructs an otpauth:// URI from the given config, decoding
+// the secret (hex or base32) and re-encoding it as unpadded base32, as
+// Google Authenticator and compatible apps expect.
+func buildOTPURI(cfg otpConfig) (string, error) {
+	if cfg.Account == "" {
+		return "", fmt.Errorf("otp account is required")
+	}
+	if cfg.Secret == "" {
+		return "", fmt.Errorf("otp secret is required")
+	}
+
+	secret, err := decodeOTPSecret(cfg.Secret, cfg.SecretFormat)
+	if err != nil {
+		return "", fmt.Errorf("invalid otp secret: %v", err)
+	}
+	b32Secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret)
+
+	digits := cfg.Digits
+	if digits == 0 {
+		digits = 6
+	}
+	algo := cfg.Algo
+	if algo == "" {
+		algo = "SHA1"
+	}
+
+	label := cfg.Account
+	if cfg.Issuer != "" {
+		label = fmt.Sprintf("%s:%s", cfg.Issuer, cfg.Account)
+	}
+
+	query := url.Values{}
+	query.Set("secret", b32Secret)
+	if cfg.Issuer != "" {
+		query.Set("issuer", cfg.Issuer)
+	}
+	query.Set("algorithm", strings.ToUpper(algo))
+	query.Set("digits", fmt.Sprintf("%d", digits))
+
+	switch cfg.Type {
+	case "hotp":
+		query.Set("counter", fmt.Sprintf("%d", cfg.Counter))
+	default:
+		period := cfg.Period
+		if period == 0 {
+			period = 30
+		}
+		query.Set("period", fmt.Sprintf("%d", period))
+	}
+
+	otpType := cfg.Type
+	if otpType == "" {
+		otpType = "totp"
+	}
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     otpType,
+		Path:     "/" + label,
+		RawQuery: query.Encode(),
+	}
+
+	return u.String(), nil
+}
+
+// generateOTPURI builds an otpauth:// URI from CLI flags, preferring the
+// "issuer:account:secret[:counter]" shorthand when given.
+func generateOTPURI(config Config, otpType string) (string, error) {
+	if otpType == "hotp" {
+		if config.HOTP != "" {
+			return otpURIFromConfig(config, otpType)
+		}
+	} else if config.TOTP != "" {
+		return otpURIFromConfig(config, otpType)
+	}
+
+	cfg := otpConfig{
+		Type:         otpType,
+		Issuer:       config.TOTPIssuer,
+		Account:      config.TOTPAccount,
+		Secret:       config.TOTPSecret,
+		Digits:       config.TOTPDigits,
+		Period:       config.TOTPPeriod,
+		Algo:         config.TOTPAlgo,
+		Counter:      config.HOTPCounter,
+		SecretFormat: config.TOTPSecretFormat,
+	}
+	return buildOTPURI(cfg)
+}
+
+func otpURIFromConfig(config Config, otpType string) (string, error) {
+	var cfg otpConfig
+	var err error
+
+	if otpType == "hotp" {
+		cfg, err = parseHOTPShorthand(config.HOTP)
+	} else {
+		cfg, err = parseTOTPShorthand(config.TOTP)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if config.TOTPDigits != 0 {
+		cfg.Digits = config.TOTPDigits
+	}
+	if config.TOTPPeriod != 0 {
+		cfg.Period = config.TOTPPeriod
+	}
+	if config.TOTPAlgo != "" {
+		cfg.Algo = config.TOTPAlgo
+	}
+	cfg.SecretFormat = config.TOTPSecretFormat
+
+	return buildOTPURI(cfg)
+}
+
+// decodeOTPSecret accepts a raw secret as hex or base32 and returns the
+// decoded bytes. format forces "hex" or "base32" when the secret is
+// ambiguous (e.g. "deadbeef" is valid as both); left empty, a secret made
+// up entirely of hex digits is treated as hex, and anything else is tried
+// as base32 — auto-detection never tries base32 first, since that would
+// silently reinterpret a valid hex secret and produce a QR with the wrong
+// key baked in.
+func decodeOTPSecret(secret, format string) ([]byte, error) {
+	trimmed := strings.TrimSpace(secret)
+
+	switch strings.ToLower(format) {
+	case "hex":
+		decoded, err := hex.DecodeString(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("not valid hex: %v", err)
+		}
+		return decoded, nil
+	case "base32":
+		clean := strings.TrimRight(strings.ToUpper(trimmed), "=")
+		decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(clean)
+		if err != nil {
+			return nil, fmt.Errorf("not valid base32: %v", err)
+		}
+		return decoded, nil
+	case "":
+		if isStrictHex(trimmed) {
+			decoded, err := hex.DecodeString(trimmed)
+			if err == nil {
+				return decoded, nil
+			}
+		}
+		clean := strings.TrimRight(strings.ToUpper(trimmed), "=")
+		if decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(clean); err == nil {
+			return decoded, nil
+		}
+		return nil, fmt.Errorf("secret must be valid hex or base32 (pass --totp-secret-format to disambiguate)")
+	default:
+		return nil, fmt.Errorf("unknown --totp-secret-format %q (expected hex or base32)", format)
+	}
+}
+
+// isStrictHex reports whether s is a non-empty, even-length string of only
+// hex digits, i.e. unambiguously decodable as hex.
+func isStrictHex(s string) bool {
+	if s == "" || len(s)%2 != 0 {
+		return false
+	}
+	for _, r := range s {
+		if !((r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTOTPShorthand parses the "issuer:account:secret" shorthand accepted
+// by --totp into a full otpConfig.
+func parseTOTPShorthand(shorthand string) (otpConfig, error) {
+	parts := strings.SplitN(shorthand, ":", 3)
+	if len(parts) != 3 {
+		return otpConfig{}, fmt.Errorf("--totp format should be 'issuer:account:secret'")
+	}
+	return otpConfig{Type: "totp", Issuer: parts[0], Account: parts[1], Secret: parts[2]}, nil
+}
+
+// parseHOTPShorthand parses the "issuer:account:secret:counter" shorthand
+// accepted by --hotp into a full otpConfig.
+func parseHOTPShorthand(shorthand string) (otpConfig, error) {
+	parts := strings.SplitN(shorthand, ":", 4)
+	if len(parts) != 4 {
+		return otpConfig{}, fmt.Errorf("--hotp format should be 'issuer:account:secret:counter'")
+	}
+	counter, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return otpConfig{}, fmt.Errorf("invalid hotp counter %q: %v", parts[3], err)
+	}
+	return otpConfig{Type: "hotp", Issuer: parts[0], Account: parts[1], Secret: parts[2], Counter: counter}, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// TestDecodeOTPSecretAutoDetectsHexOverBase32 checks the ambiguous case the
+// auto-detect logic exists for: a secret that is valid as both hex and
+// base32 must decode as hex, since reinterpreting it as base32 would
+// silently bake the wrong key into the QR.
+func TestDecodeOTPSecretAutoDetectsHexOverBase32(t *testing.T) {
+	secret := "deadbeef"
+
+	got, err := decodeOTPSecret(secret, "")
+	if err != nil {
+		t.Fatalf("decodeOTPSecret(%q, \"\"): %v", secret, err)
+	}
+
+	want, _ := hex.DecodeString(secret)
+	if string(got) != string(want) {
+		t.Fatalf("decodeOTPSecret(%q, \"\") = %x, want %x (hex)", secret, got, want)
+	}
+}
+
+// TestDecodeOTPSecretFormatOverride checks that an explicit
+// --totp-secret-format forces the requested encoding even when the secret
+// would otherwise auto-detect as hex.
+func TestDecodeOTPSecretFormatOverride(t *testing.T) {
+	secret := "deadbeef"
+
+	got, err := decodeOTPSecret(secret, "base32")
+	if err != nil {
+		t.Fatalf("decodeOTPSecret(%q, \"base32\"): %v", secret, err)
+	}
+
+	want, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		t.Fatalf("reference base32 decode: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("decodeOTPSecret(%q, \"base32\") = %x, want %x", secret, got, want)
+	}
+}
+
+// TestDecodeOTPSecretBase32Only checks a secret that is only valid base32
+// (contains letters outside a-f) still auto-detects correctly.
+func TestDecodeOTPSecretBase32Only(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+
+	got, err := decodeOTPSecret(secret, "")
+	if err != nil {
+		t.Fatalf("decodeOTPSecret(%q, \"\"): %v", secret, err)
+	}
+
+	want, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("reference base32 decode: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("decodeOTPSecret(%q, \"\") = %x, want %x", secret, got, want)
+	}
+}
+
+// TestDecodeOTPSecretRejectsUnknownFormat checks an unrecognized
+// --totp-secret-format value is rejected rather than silently ignored.
+func TestDecodeOTPSecretRejectsUnknownFormat(t *testing.T) {
+	if _, err := decodeOTPSecret("deadbeef", "rot13"); err == nil {
+		t.Fatalf("decodeOTPSecret with format %q: expected error, got nil", "rot13")
+	}
+}
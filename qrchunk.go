@@ -0,0 +1,224 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// chunkEnvelopeOverhead is a conservative upper bound on the non-payload
+// bytes in a "CHUNK:<uuid>:<index>/<total>:<base64-payload>" envelope
+// (prefix, UUID, separators, and up to 4-digit index/total).
+const chunkEnvelopeOverhead = len("CHUNK:") + 36 + len(":") + 4 + len("/") + 4 + len(":")
+
+// chunkCapacity returns the approximate byte-mode character capacity of a
+// single QR code at the given recovery level, as used to size chunks.
+func chunkCapacity(level qrcode.RecoveryLevel) int {
+	switch level {
+	case qrcode.Low:
+		return 2953
+	case qrcode.Medium:
+		return 2331
+	case qrcode.High:
+		return 1663
+	case qrcode.Highest:
+		return 1273
+	default:
+		return 2331
+	}
+}
+
+type chunkEnvelope struct {
+	ID      string
+	Index   int
+	Total   int
+	Payload []byte
+}
+
+func newChunkID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("cannot generate chunk id: %v", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// formatChunkEnvelope renders a single chunk as "CHUNK:<uuid>:<index>/<total>:<base64-payload>".
+func formatChunkEnvelope(id string, index, total int, payload []byte) string {
+	return fmt.Sprintf("CHUNK:%s:%d/%d:%s", id, index, total, base64.StdEncoding.EncodeToString(payload))
+}
+
+// parseChunkEnvelope validates and parses a chunk envelope string.
+func parseChunkEnvelope(s string) (*chunkEnvelope, error) {
+	if !strings.HasPrefix(s, "CHUNK:") {
+		return nil, fmt.Errorf("not a chunk envelope")
+	}
+	parts := strings.SplitN(s, ":", 4)
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("malformed chunk envelope: expected 4 fields")
+	}
+
+	idxTotal := strings.SplitN(parts[2], "/", 2)
+	if len(idxTotal) != 2 {
+		return nil, fmt.Errorf("malformed chunk envelope: expected index/total")
+	}
+
+	index, err := strconv.Atoi(idxTotal[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed chunk index: %v", err)
+	}
+	total, err := strconv.Atoi(idxTotal[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed chunk total: %v", err)
+	}
+	if index < 1 || total < 1 || index > total {
+		return nil, fmt.Errorf("invalid chunk index %d of %d", index, total)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, fmt.Errorf("malformed chunk payload: %v", err)
+	}
+
+	return &chunkEnvelope{ID: parts[1], Index: index, Total: total, Payload: payload}, nil
+}
+
+// splitIntoChunks breaks data into ordered envelope strings sized to fit a
+// single QR code at the given recovery level.
+func splitIntoChunks(data []byte, chunkSize int, level qrcode.RecoveryLevel) ([]string, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("cannot chunk empty payload")
+	}
+
+	capacity := chunkSize
+	if capacity <= 0 {
+		capacity = chunkCapacity(level)
+	}
+	rawPerChunk := ((capacity - chunkEnvelopeOverhead) * 3) / 4
+	if rawPerChunk <= 0 {
+		return nil, fmt.Errorf("chunk size %d is too small to fit the envelope overhead", capacity)
+	}
+
+	total := (len(data) + rawPerChunk - 1) / rawPerChunk
+
+	id, err := newChunkID()
+	if err != nil {
+		return nil, err
+	}
+
+	envelopes := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		start := i * rawPerChunk
+		end := start + rawPerChunk
+		if end > len(data) {
+			end = len(data)
+		}
+		envelopes = append(envelopes, formatChunkEnvelope(id, i+1, total, data[start:end]))
+	}
+
+	return envelopes, nil
+}
+
+// writeChunkQRCodes splits data into chunk envelopes and writes each as
+// "<prefix>_NNN.png" using style, returning the paths written in order.
+func writeChunkQRCodes(data []byte, prefix string, chunkSize, size int, level qrcode.RecoveryLevel, style qrStyle) ([]string, error) {
+	envelopes, err := splitIntoChunks(data, chunkSize, level)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(envelopes))
+	for i, envelope := range envelopes {
+		outputPath := fmt.Sprintf("%s_%03d.png", prefix, i+1)
+		if err := generateStyledQRCode(envelope, outputPath, size, level, "", style, false); err != nil {
+			return nil, fmt.Errorf("cannot write chunk %d/%d: %v", i+1, len(envelopes), err)
+		}
+		paths = append(paths, outputPath)
+	}
+
+	return paths, nil
+}
+
+// reassembleChunks reads every image in dir, decodes it as a QR code,
+// parses the chunk envelope, and reassembles the original payload by index.
+// It reports an error if any index in [1, total] is missing or if the same
+// index appears with conflicting payloads.
+func reassembleChunks(dir string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read chunk directory %s: %v", dir, err)
+	}
+
+	chunksByIndex := make(map[int]*chunkEnvelope)
+	var id string
+	total := 0
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		content, err := decodeQRCodeFile(path)
+		if err != nil {
+			continue // skip non-QR files in the directory
+		}
+
+		envelope, err := parseChunkEnvelope(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", entry.Name(), err)
+		}
+
+		if id == "" {
+			id = envelope.ID
+			total = envelope.Total
+		} else if envelope.ID != id {
+			return nil, fmt.Errorf("%s belongs to a different chunk set (%s != %s)", entry.Name(), envelope.ID, id)
+		} else if envelope.Total != total {
+			return nil, fmt.Errorf("%s has inconsistent total %d (expected %d)", entry.Name(), envelope.Total, total)
+		}
+
+		if existing, ok := chunksByIndex[envelope.Index]; ok {
+			if string(existing.Payload) != string(envelope.Payload) {
+				return nil, fmt.Errorf("duplicate chunk %d with conflicting payloads", envelope.Index)
+			}
+			continue
+		}
+		chunksByIndex[envelope.Index] = envelope
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("no valid chunk envelopes found in %s", dir)
+	}
+
+	var missing []int
+	for i := 1; i <= total; i++ {
+		if _, ok := chunksByIndex[i]; !ok {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing chunk(s) %v of %d", missing, total)
+	}
+
+	indexes := make([]int, 0, total)
+	for i := range chunksByIndex {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	var payload []byte
+	for _, i := range indexes {
+		payload = append(payload, chunksByIndex[i].Payload...)
+	}
+
+	return payload, nil
+}
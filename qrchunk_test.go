@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// TestSplitAndReassembleChunks exercises splitIntoChunks and
+// parseChunkEnvelope end to end: splitting a payload larger than one
+// chunk's capacity must produce envelopes that parse back, in order, to
+// the original bytes.
+func TestSplitAndReassembleChunks(t *testing.T) {
+	data := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog. "), 200)
+
+	envelopes, err := splitIntoChunks(data, 200, qrcode.Medium)
+	if err != nil {
+		t.Fatalf("splitIntoChunks: %v", err)
+	}
+	if len(envelopes) < 2 {
+		t.Fatalf("expected payload to split across multiple chunks, got %d", len(envelopes))
+	}
+
+	var reassembled []byte
+	var id string
+	for i, raw := range envelopes {
+		envelope, err := parseChunkEnvelope(raw)
+		if err != nil {
+			t.Fatalf("parseChunkEnvelope(%d): %v", i, err)
+		}
+		if envelope.Total != len(envelopes) {
+			t.Fatalf("envelope %d: total = %d, want %d", i, envelope.Total, len(envelopes))
+		}
+		if envelope.Index != i+1 {
+			t.Fatalf("envelope %d: index = %d, want %d", i, envelope.Index, i+1)
+		}
+		if id == "" {
+			id = envelope.ID
+		} else if envelope.ID != id {
+			t.Fatalf("envelope %d: id %q != first envelope's id %q", i, envelope.ID, id)
+		}
+		reassembled = append(reassembled, envelope.Payload...)
+	}
+
+	if !bytes.Equal(reassembled, data) {
+		t.Fatalf("reassembled payload does not match original (got %d bytes, want %d)", len(reassembled), len(data))
+	}
+}
+
+// TestParseChunkEnvelopeRejectsMalformed checks that obviously broken
+// envelopes are rejected rather than silently misparsed.
+func TestParseChunkEnvelopeRejectsMalformed(t *testing.T) {
+	cases := []string{
+		"not a chunk envelope",
+		"CHUNK:id-only",
+		"CHUNK:id:0/1:aGVsbG8=", // index 0 is out of range
+		"CHUNK:id:2/1:aGVsbG8=", // index > total
+		"CHUNK:id:1/1:not-base64!",
+	}
+	for _, c := range cases {
+		if _, err := parseChunkEnvelope(c); err == nil {
+			t.Errorf("parseChunkEnvelope(%q): expected error, got nil", c)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// qrStyle carries the visual options that shape how a QR bitmap is
+// rendered to an image: module colors, quiet zone, transparency, and an
+// optional centered logo overlay.
+type qrStyle struct {
+	FG          color.RGBA
+	BG          color.RGBA
+	Transparent bool
+	Margin      int // quiet zone, in modules, added on each side
+	Logo        string
+	LogoScale   float64
+}
+
+var defaultStyle = qrStyle{FG: color.RGBA{0, 0, 0, 255}, BG: color.RGBA{255, 255, 255, 255}, LogoScale: 0.2}
+
+// resolveStyle builds a qrStyle from CLI flags, validating colors,
+// foreground/background contrast, margin, and logo size along the way.
+func resolveStyle(config Config) (qrStyle, error) {
+	style := defaultStyle
+
+	if config.FG != "" {
+		fg, err := parseHexColor(config.FG)
+		if err != nil {
+			return style, fmt.Errorf("invalid --fg: %v", err)
+		}
+		style.FG = fg
+	}
+
+	if config.BG != "" {
+		bg, err := parseHexColor(config.BG)
+		if err != nil {
+			return style, fmt.Errorf("invalid --bg: %v", err)
+		}
+		style.BG = bg
+	}
+
+	style.Transparent = config.Transparent
+	style.Margin = config.Margin
+	if style.Margin < 0 {
+		return style, fmt.Errorf("--margin must be >= 0, got %d", style.Margin)
+	}
+
+	if config.Logo != "" {
+		scale := config.LogoScale
+		if scale == 0 {
+			scale = defaultStyle.LogoScale
+		}
+		if scale > 0.25 {
+			return style, fmt.Errorf("--logo-scale %.2f exceeds the maximum of 0.25 (logo would obscure too much of the QR to stay scannable)", scale)
+		}
+		style.Logo = config.Logo
+		style.LogoScale = scale
+	}
+
+	if !colorsDistinct(style.FG, style.BG) {
+		return style, fmt.Errorf("--fg and --bg are too similar; choose colors with enough contrast to stay scannable")
+	}
+
+	return style, nil
+}
+
+// parseHexColor parses a "#rrggbb" or "#rgb" hex color string.
+func parseHexColor(s string) (color.RGBA, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return color.RGBA{}, fmt.Errorf("expected #rrggbb or #rgb, got %q", s)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid hex color %q: %v", s, err)
+	}
+
+	return color.RGBA{
+		R: uint8(v >> 16),
+		G: uint8(v >> 8),
+		B: uint8(v),
+		A: 255,
+	}, nil
+}
+
+// colorsDistinct rejects foreground/background pairs close enough in
+// perceptual brightness that a scanner couldn't tell modules apart.
+func colorsDistinct(a, b color.RGBA) bool {
+	luminance := func(c color.RGBA) float64 {
+		return 0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)
+	}
+	return math.Abs(luminance(a)-luminance(b)) >= 64
+}
+
+// renderStyledImage rasterizes a QR bitmap to an RGBA image of size x size
+// pixels using the given style's colors, quiet zone, and transparency, then
+// composites a logo on top if one is configured.
+func renderStyledImage(bitmap [][]bool, size int, style qrStyle) (*image.RGBA, error) {
+	modules := len(bitmap) + 2*style.Margin
+	scale := size / modules
+	if scale == 0 {
+		scale = 1
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	bg := style.BG
+	if style.Transparent {
+		bg = color.RGBA{}
+	}
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			mx := x/scale - style.Margin
+			my := y/scale - style.Margin
+
+			if mx < 0 || my < 0 || mx >= len(bitmap) || my >= len(bitmap) || !bitmap[my][mx] {
+				img.Set(x, y, bg)
+			} else {
+				img.Set(x, y, style.FG)
+			}
+		}
+	}
+
+	if style.Logo != "" {
+		if err := overlayLogo(img, style.Logo, style.LogoScale); err != nil {
+			return nil, err
+		}
+	}
+
+	return img, nil
+}
+
+// overlayLogo resizes the image at logoPath to logoScale of base's area and
+// composites it centered on top of base.
+func overlayLogo(base *image.RGBA, logoPath string, logoScale float64) error {
+	file, err := os.Open(logoPath)
+	if err != nil {
+		return fmt.Errorf("cannot open logo %s: %v", logoPath, err)
+	}
+	defer file.Close()
+
+	logo, _, err := image.Decode(file)
+	if err != nil {
+		return fmt.Errorf("cannot decode logo %s: %v", logoPath, err)
+	}
+
+	size := base.Bounds().Dx()
+	logoSize := int(float64(size) * math.Sqrt(logoScale))
+	resized := resizeNearest(logo, logoSize, logoSize)
+
+	offset := (size - logoSize) / 2
+	rect := image.Rect(offset, offset, offset+logoSize, offset+logoSize)
+	draw.Draw(base, rect, resized, image.Point{}, draw.Over)
+
+	return nil
+}
+
+// logoDataURI reads and decodes logoPath (validating it's a real image) and
+// returns it as a "data:image/<format>;base64,..." URI, for formats like
+// SVG that embed the logo rather than rasterizing it onto the QR bitmap.
+func logoDataURI(logoPath string) (string, error) {
+	data, err := os.ReadFile(logoPath)
+	if err != nil {
+		return "", fmt.Errorf("cannot open logo %s: %v", logoPath, err)
+	}
+
+	_, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("cannot decode logo %s: %v", logoPath, err)
+	}
+
+	return fmt.Sprintf("data:image/%s;base64,%s", format, base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// resizeNearest scales src to w x h using nearest-neighbor sampling.
+func resizeNearest(src image.Image, w, h int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sx := bounds.Min.X + x*srcW/w
+			sy := bounds.Min.Y + y*srcH/h
+			dst.Set(x, y, src.At(sx, sy))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// vCardFields holds the structured contact fields assembled into a vCard
+// or MeCard payload.
+type vCardFields struct {
+	Name  string
+	Org   string
+	Title string
+	Tel   string
+	Email string
+	Adr   string
+	URL   string
+	Note  string
+	Photo string
+}
+
+func vCardFieldsFromConfig(config Config) vCardFields {
+	return vCardFields{
+		Name:  config.VCardName,
+		Org:   config.VCardOrg,
+		Title: config.VCardTitle,
+		Tel:   config.VCardTel,
+		Email: config.VCardEmail,
+		Adr:   config.VCardAdr,
+		URL:   config.VCardURLField,
+		Note:  config.VCardNote,
+		Photo: config.VCardPhoto,
+	}
+}
+
+func hasVCardFields(config Config) bool {
+	f := vCardFieldsFromConfig(config)
+	return f.Name != "" || f.Org != "" || f.Title != "" || f.Tel != "" ||
+		f.Email != "" || f.Adr != "" || f.URL != "" || f.Note != "" || f.Photo != ""
+}
+
+// buildVCard assembles an in-memory vCard 3.0 payload from structured
+// contact fields.
+func buildVCard(f vCardFields) (string, error) {
+	if f.Name == "" {
+		return "", fmt.Errorf("--name is required to build a vCard")
+	}
+
+	var b strings.Builder
+	b.WriteString("BEGIN:VCARD\nVERSION:3.0\n")
+	fmt.Fprintf(&b, "N:;%s;;;\n", f.Name)
+	fmt.Fprintf(&b, "FN:%s\n", f.Name)
+	if f.Org != "" {
+		fmt.Fprintf(&b, "ORG:%s\n", f.Org)
+	}
+	if f.Title != "" {
+		fmt.Fprintf(&b, "TITLE:%s\n", f.Title)
+	}
+	if f.Tel != "" {
+		fmt.Fprintf(&b, "TEL;TYPE=CELL:%s\n", f.Tel)
+	}
+	if f.Email != "" {
+		fmt.Fprintf(&b, "EMAIL:%s\n", f.Email)
+	}
+	if f.Adr != "" {
+		fmt.Fprintf(&b, "ADR:;;%s;;;;\n", f.Adr)
+	}
+	if f.URL != "" {
+		fmt.Fprintf(&b, "URL:%s\n", f.URL)
+	}
+	if f.Note != "" {
+		fmt.Fprintf(&b, "NOTE:%s\n", f.Note)
+	}
+	if f.Photo != "" {
+		encoded, mimeType, err := encodeVCardPhoto(f.Photo)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "PHOTO;ENCODING=BASE64;TYPE=%s:%s\n", mimeType, encoded)
+	}
+	b.WriteString("END:VCARD\n")
+
+	return b.String(), nil
+}
+
+func encodeVCardPhoto(path string) (encoded, mimeType string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot read photo %s: %v", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		mimeType = "JPEG"
+	case ".png":
+		mimeType = "PNG"
+	case ".gif":
+		mimeType = "GIF"
+	default:
+		mimeType = "JPEG"
+	}
+
+	return base64.StdEncoding.EncodeToString(data), mimeType, nil
+}
+
+// buildMeCard assembles the compact MeCard format some feature phones and
+// older scanners expect instead of a full vCard.
+func buildMeCard(f vCardFields) (string, error) {
+	if f.Name == "" {
+		return "", fmt.Errorf("--name is required to build a MeCard")
+	}
+
+	var b strings.Builder
+	b.WriteString("MECARD:")
+	fmt.Fprintf(&b, "N:%s;", f.Name)
+	if f.Tel != "" {
+		fmt.Fprintf(&b, "TEL:%s;", f.Tel)
+	}
+	if f.Email != "" {
+		fmt.Fprintf(&b, "EMAIL:%s;", f.Email)
+	}
+	if f.Org != "" {
+		fmt.Fprintf(&b, "ORG:%s;", f.Org)
+	}
+	if f.Adr != "" {
+		fmt.Fprintf(&b, "ADR:%s;", f.Adr)
+	}
+	if f.Note != "" {
+		fmt.Fprintf(&b, "NOTE:%s;", f.Note)
+	}
+	if f.URL != "" {
+		fmt.Fprintf(&b, "URL:%s;", f.URL)
+	}
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// buildSMS renders an "+123:Hello" argument as an "SMSTO:+123:Hello" payload.
+func buildSMS(arg string) (string, error) {
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", fmt.Errorf("--sms format should be '+number:message'")
+	}
+	return fmt.Sprintf("SMSTO:%s:%s", parts[0], parts[1]), nil
+}
+
+// buildMailto renders a "address[?query]" argument as a mailto: URI.
+func buildMailto(arg string) (string, error) {
+	if arg == "" {
+		return "", fmt.Errorf("--mailto requires an email address")
+	}
+	return "mailto:" + arg, nil
+}
+
+// buildGeo renders a "lat,lon[,alt]" argument as a geo: URI.
+func buildGeo(arg string) (string, error) {
+	parts := strings.Split(arg, ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", fmt.Errorf("--geo format should be 'lat,lon' or 'lat,lon,alt'")
+	}
+	return "geo:" + arg, nil
+}
+
+// buildTelURI renders a phone number as a tel: URI.
+func buildTelURI(arg string) (string, error) {
+	if arg == "" {
+		return "", fmt.Errorf("--tel requires a phone number")
+	}
+	return "tel:" + arg, nil
+}
+
+// buildEvent assembles an iCal VEVENT payload from the --event-* flags.
+func buildEvent(config Config) (string, error) {
+	if config.EventSummary == "" || config.EventStart == "" {
+		return "", fmt.Errorf("--event requires at least --event-summary and --event-start")
+	}
+
+	var b strings.Builder
+	b.WriteString("BEGIN:VEVENT\n")
+	fmt.Fprintf(&b, "SUMMARY:%s\n", config.EventSummary)
+	fmt.Fprintf(&b, "DTSTART:%s\n", config.EventStart)
+	if config.EventEnd != "" {
+		fmt.Fprintf(&b, "DTEND:%s\n", config.EventEnd)
+	}
+	if config.EventLocation != "" {
+		fmt.Fprintf(&b, "LOCATION:%s\n", config.EventLocation)
+	}
+	b.WriteString("END:VEVENT\n")
+
+	return b.String(), nil
+}